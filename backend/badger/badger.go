@@ -0,0 +1,147 @@
+// Package badger implements the backend.KV interface on top of
+// dgraph-io/badger.
+package badger
+
+import (
+	"fmt"
+
+	badger "github.com/dgraph-io/badger/v4"
+
+	"github.com/five-vee/db-cmp/backend"
+)
+
+func init() {
+	backend.Register("badger", func() backend.KV { return &badgerKV{} })
+}
+
+type badgerKV struct {
+	db *badger.DB
+}
+
+func (b *badgerKV) Open(path string) error {
+	opts := badger.DefaultOptions(path).WithLogger(nil)
+	db, err := badger.Open(opts)
+	if err != nil {
+		return fmt.Errorf("badger: failed to open: %w", err)
+	}
+	b.db = db
+	return nil
+}
+
+func (b *badgerKV) Seed(n int) ([][]byte, error) {
+	keys := make([][]byte, n)
+	err := b.db.Update(func(txn *badger.Txn) error {
+		for i := 0; i < n; i++ {
+			k, v := backend.RandomKV()
+			if err := txn.Set(k, v); err != nil {
+				return fmt.Errorf("failed to Set key i=%d: %w", i, err)
+			}
+			keys[i] = k
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (b *badgerKV) Scan(fn func(k, v []byte) bool) error {
+	return b.db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			cont := true
+			err := item.Value(func(v []byte) error {
+				cont = fn(item.Key(), v)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if !cont {
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+func (b *badgerKV) ScanRange(seek []byte, n int, fn func(k, v []byte) bool) error {
+	return b.db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+		i := 0
+		for it.Seek(seek); it.Valid() && i < n; it.Next() {
+			item := it.Item()
+			cont := true
+			err := item.Value(func(v []byte) error {
+				cont = fn(item.Key(), v)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if !cont {
+				return nil
+			}
+			i++
+		}
+		return nil
+	})
+}
+
+func (b *badgerKV) Get(k []byte) ([]byte, bool, error) {
+	var v []byte
+	var ok bool
+	err := b.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(k)
+		if err == badger.ErrKeyNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			v = append([]byte(nil), val...)
+			ok = true
+			return nil
+		})
+	})
+	return v, ok, err
+}
+
+func (b *badgerKV) Put(k, v []byte) error {
+	return b.db.Update(func(txn *badger.Txn) error {
+		return txn.Set(k, v)
+	})
+}
+
+func (b *badgerKV) Delete(k []byte) error {
+	return b.db.Update(func(txn *badger.Txn) error {
+		return txn.Delete(k)
+	})
+}
+
+func (b *badgerKV) Batch(ops []backend.BatchOp) error {
+	return b.db.Update(func(txn *badger.Txn) error {
+		for _, op := range ops {
+			switch op.Kind {
+			case backend.OpSet:
+				if err := txn.Set(op.Key, op.Value); err != nil {
+					return err
+				}
+			case backend.OpDelete:
+				if err := txn.Delete(op.Key); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (b *badgerKV) Close() error {
+	return b.db.Close()
+}
@@ -0,0 +1,130 @@
+// Package boltdb implements the backend.KV interface on top of BoltDB.
+package boltdb
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/boltdb/bolt"
+
+	"github.com/five-vee/db-cmp/backend"
+)
+
+const bucketName = "MyBucket"
+
+func init() {
+	backend.Register("bolt", func() backend.KV { return &boltKV{} })
+}
+
+type boltKV struct {
+	db *bolt.DB
+}
+
+func (b *boltKV) Open(path string) error {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return fmt.Errorf("boltdb: failed to open: %w", err)
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(bucketName))
+		return err
+	}); err != nil {
+		db.Close()
+		return fmt.Errorf("boltdb: failed to create bucket: %w", err)
+	}
+	b.db = db
+	return nil
+}
+
+func (b *boltKV) Seed(n int) ([][]byte, error) {
+	keys := make([][]byte, n)
+	err := b.db.Batch(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket([]byte(bucketName))
+		for i := 0; i < n; i++ {
+			k, v := backend.RandomKV()
+			if err := bkt.Put(k, v); err != nil {
+				return fmt.Errorf("failed to Put key i=%d: %w", i, err)
+			}
+			keys[i] = k
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (b *boltKV) Scan(fn func(k, v []byte) bool) error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(bucketName)).Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			if !fn(k, v) {
+				break
+			}
+		}
+		return nil
+	})
+}
+
+func (b *boltKV) ScanRange(seek []byte, n int, fn func(k, v []byte) bool) error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(bucketName)).Cursor()
+		i := 0
+		for k, v := c.Seek(seek); k != nil && i < n; k, v = c.Next() {
+			if !fn(k, v) {
+				break
+			}
+			i++
+		}
+		return nil
+	})
+}
+
+func (b *boltKV) Get(k []byte) ([]byte, bool, error) {
+	var v []byte
+	var ok bool
+	err := b.db.View(func(tx *bolt.Tx) error {
+		if found := tx.Bucket([]byte(bucketName)).Get(k); found != nil {
+			v = append([]byte(nil), found...)
+			ok = true
+		}
+		return nil
+	})
+	return v, ok, err
+}
+
+func (b *boltKV) Put(k, v []byte) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(bucketName)).Put(k, v)
+	})
+}
+
+func (b *boltKV) Delete(k []byte) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(bucketName)).Delete(k)
+	})
+}
+
+func (b *boltKV) Batch(ops []backend.BatchOp) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket([]byte(bucketName))
+		for _, op := range ops {
+			switch op.Kind {
+			case backend.OpSet:
+				if err := bkt.Put(op.Key, op.Value); err != nil {
+					return err
+				}
+			case backend.OpDelete:
+				if err := bkt.Delete(op.Key); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (b *boltKV) Close() error {
+	return b.db.Close()
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package boltdb
+
+import "fmt"
+
+// Prefault is not supported on this platform; madvise is POSIX-only.
+func (b *boltKV) Prefault() error {
+	return fmt.Errorf("boltdb: mmap-prefault warmup is not supported on this platform")
+}
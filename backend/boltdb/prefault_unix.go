@@ -0,0 +1,56 @@
+//go:build unix
+
+package boltdb
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// Prefault pages the entire BoltDB file into the OS page cache, using a
+// throwaway read-only mmap of the file rather than reaching into
+// BoltDB's own internal one. madvise(MADV_WILLNEED) only issues a
+// non-blocking readahead hint, so it alone can return before pages are
+// actually resident, pushing the page-in cost into the timed run. After
+// the hint, Prefault also touches one byte per page itself, which
+// blocks until that page is faulted in, so the call doesn't return
+// until the data is genuinely cached.
+func (b *boltKV) Prefault() error {
+	f, err := os.Open(b.db.Path())
+	if err != nil {
+		return fmt.Errorf("boltdb: failed to open file for prefault: %w", err)
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("boltdb: failed to stat file for prefault: %w", err)
+	}
+	if fi.Size() == 0 {
+		return nil
+	}
+
+	data, err := unix.Mmap(int(f.Fd()), 0, int(fi.Size()), unix.PROT_READ, unix.MAP_SHARED)
+	if err != nil {
+		return fmt.Errorf("boltdb: mmap failed: %w", err)
+	}
+	defer unix.Munmap(data)
+
+	if err := unix.Madvise(data, unix.MADV_WILLNEED); err != nil {
+		return fmt.Errorf("boltdb: madvise(WILLNEED) failed: %w", err)
+	}
+
+	pageSize := os.Getpagesize()
+	var sum byte
+	for i := 0; i < len(data); i += pageSize {
+		sum += data[i]
+	}
+	prefaultSink = sum
+	return nil
+}
+
+// prefaultSink receives the bytes Prefault reads, so the compiler keeps
+// the per-page loads instead of eliminating them as dead code.
+var prefaultSink byte
@@ -0,0 +1,93 @@
+// Package backend defines the pluggable key-value store interface that
+// db-cmp benchmarks against, along with a factory registry so new engines
+// (BoltDB, LevelDB, Badger, ...) can be added without touching the
+// benchmark harness in package main.
+package backend
+
+import "fmt"
+
+// KV is the minimal set of operations a backend must support to be
+// driven by the db-cmp benchmark harness.
+type KV interface {
+	// Open opens (creating if necessary) the store at path.
+	Open(path string) error
+	// Seed inserts n random key-value pairs for benchmark setup and
+	// returns the keys that were written, so callers can sample from a
+	// known keyspace for point reads and seeks.
+	Seed(n int) ([][]byte, error)
+	// Scan iterates over every key-value pair in the store, invoking fn
+	// for each one. fn must not retain the byte slices it is given.
+	// Iteration stops early if fn returns false.
+	Scan(fn func(k, v []byte) bool) error
+	// ScanRange iterates over up to n key-value pairs starting at the
+	// first key >= seek, invoking fn for each one. fn must not retain
+	// the byte slices it is given. Iteration stops early if fn returns
+	// false.
+	ScanRange(seek []byte, n int, fn func(k, v []byte) bool) error
+	// Get looks up a single key, reporting whether it was found.
+	Get(k []byte) (v []byte, found bool, err error)
+	// Put writes a single key-value pair.
+	Put(k, v []byte) error
+	// Delete removes a single key. Deleting a key that doesn't exist is
+	// not an error.
+	Delete(k []byte) error
+	// Batch atomically applies a sequence of Put/Delete operations in a
+	// single native transaction, rather than one transaction per op.
+	Batch(ops []BatchOp) error
+	// Close releases any resources held by the store.
+	Close() error
+}
+
+// OpKind identifies the operation a BatchOp performs.
+type OpKind int
+
+const (
+	// OpSet writes BatchOp.Value to BatchOp.Key.
+	OpSet OpKind = iota
+	// OpDelete removes BatchOp.Key.
+	OpDelete
+)
+
+// BatchOp is a single operation to apply as part of a Batch call.
+type BatchOp struct {
+	Kind  OpKind
+	Key   []byte
+	Value []byte
+}
+
+// Prefaulter is optionally implemented by a KV backend that can page its
+// on-disk data into the OS page cache ahead of time, independent of
+// (and typically cheaper than) a full logical Scan.
+type Prefaulter interface {
+	Prefault() error
+}
+
+// Factory constructs a new, unopened KV implementation.
+type Factory func() KV
+
+var registry = map[string]Factory{}
+
+// Register makes a backend available under name. It is intended to be
+// called from the init function of a backend's package.
+func Register(name string, factory Factory) {
+	registry[name] = factory
+}
+
+// New constructs the backend registered under name. It returns an error
+// if no backend has been registered under that name.
+func New(name string) (KV, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("backend: unknown backend %q (known: %v)", name, Names())
+	}
+	return factory(), nil
+}
+
+// Names returns the names of all registered backends.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	return names
+}
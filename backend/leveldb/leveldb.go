@@ -0,0 +1,102 @@
+// Package leveldb implements the backend.KV interface on top of
+// syndtr/goleveldb, Go's port of LevelDB.
+package leveldb
+
+import (
+	"fmt"
+
+	"github.com/syndtr/goleveldb/leveldb"
+
+	"github.com/five-vee/db-cmp/backend"
+)
+
+func init() {
+	backend.Register("leveldb", func() backend.KV { return &levelKV{} })
+}
+
+type levelKV struct {
+	db *leveldb.DB
+}
+
+func (l *levelKV) Open(path string) error {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return fmt.Errorf("leveldb: failed to open: %w", err)
+	}
+	l.db = db
+	return nil
+}
+
+func (l *levelKV) Seed(n int) ([][]byte, error) {
+	batch := new(leveldb.Batch)
+	keys := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		k, v := backend.RandomKV()
+		batch.Put(k, v)
+		keys[i] = k
+	}
+	if err := l.db.Write(batch, nil); err != nil {
+		return nil, fmt.Errorf("leveldb: failed to seed: %w", err)
+	}
+	return keys, nil
+}
+
+func (l *levelKV) Scan(fn func(k, v []byte) bool) error {
+	iter := l.db.NewIterator(nil, nil)
+	defer iter.Release()
+	for iter.Next() {
+		if !fn(iter.Key(), iter.Value()) {
+			break
+		}
+	}
+	return iter.Error()
+}
+
+func (l *levelKV) ScanRange(seek []byte, n int, fn func(k, v []byte) bool) error {
+	iter := l.db.NewIterator(nil, nil)
+	defer iter.Release()
+	i := 0
+	for ok := iter.Seek(seek); ok && i < n; ok = iter.Next() {
+		if !fn(iter.Key(), iter.Value()) {
+			break
+		}
+		i++
+	}
+	return iter.Error()
+}
+
+func (l *levelKV) Get(k []byte) ([]byte, bool, error) {
+	v, err := l.db.Get(k, nil)
+	if err == leveldb.ErrNotFound {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return v, true, nil
+}
+
+func (l *levelKV) Put(k, v []byte) error {
+	return l.db.Put(k, v, nil)
+}
+
+func (l *levelKV) Delete(k []byte) error {
+	return l.db.Delete(k, nil)
+}
+
+func (l *levelKV) Batch(ops []backend.BatchOp) error {
+	batch := new(leveldb.Batch)
+	for _, op := range ops {
+		switch op.Kind {
+		case backend.OpSet:
+			batch.Put(op.Key, op.Value)
+		case backend.OpDelete:
+			batch.Delete(op.Key)
+		}
+	}
+	return l.db.Write(batch, nil)
+}
+
+func (l *levelKV) Close() error {
+	return l.db.Close()
+}
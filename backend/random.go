@@ -0,0 +1,48 @@
+package backend
+
+import "math/rand"
+
+const (
+	maxKeyLength   = 1000
+	maxValueLength = 1000
+)
+
+const alphanumericCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// RandomAlphanumeric generates a random string of the specified length
+// containing only alphanumeric characters.
+func RandomAlphanumeric(length int) string {
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = alphanumericCharset[rand.Intn(len(alphanumericCharset))]
+	}
+	return string(b)
+}
+
+// RandomKV generates a random key and value suitable for seeding a
+// backend, with lengths bounded by the package's max key/value sizes.
+func RandomKV() (k, v []byte) {
+	keyLen := rand.Intn(maxKeyLength) + 1
+	valLen := rand.Intn(maxValueLength) + 1
+	return []byte(RandomAlphanumeric(keyLen)), []byte(RandomAlphanumeric(valLen))
+}
+
+// RandomAlphanumericFrom is like RandomAlphanumeric but draws from r
+// instead of the global math/rand source, so callers seeding r (e.g. via
+// -seed) get output that doesn't depend on goroutine-scheduling order.
+func RandomAlphanumericFrom(r *rand.Rand, length int) string {
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = alphanumericCharset[r.Intn(len(alphanumericCharset))]
+	}
+	return string(b)
+}
+
+// RandomKVFrom is like RandomKV but draws from r instead of the global
+// math/rand source, so callers seeding r (e.g. via -seed) get output
+// that doesn't depend on goroutine-scheduling order.
+func RandomKVFrom(r *rand.Rand) (k, v []byte) {
+	keyLen := r.Intn(maxKeyLength) + 1
+	valLen := r.Intn(maxValueLength) + 1
+	return []byte(RandomAlphanumericFrom(r, keyLen)), []byte(RandomAlphanumericFrom(r, valLen))
+}
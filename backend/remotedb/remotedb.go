@@ -0,0 +1,153 @@
+// Package remotedb implements the backend.KV interface by driving a
+// RemoteDB gRPC server (see cmd/db-cmp-server), so the benchmark harness
+// can measure the round-trip cost of a backend that lives in another
+// process.
+package remotedb
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/five-vee/db-cmp/backend"
+	pb "github.com/five-vee/db-cmp/proto/remotedb"
+)
+
+func init() {
+	backend.Register("remote", func() backend.KV { return &remoteKV{} })
+}
+
+// Addr is the address of the db-cmp-server to dial. It must be set
+// before the "remote" backend is opened.
+var Addr string
+
+// UseTLS selects whether to dial Addr over TLS. It has no effect unless
+// set before the "remote" backend is opened.
+var UseTLS bool
+
+type remoteKV struct {
+	conn   *grpc.ClientConn
+	client pb.RemoteDBClient
+}
+
+// Open dials the RemoteDB server configured via Addr/UseTLS. path is
+// unused; the remote backend's on-disk location is chosen by the
+// server.
+func (r *remoteKV) Open(path string) error {
+	if Addr == "" {
+		return fmt.Errorf("remotedb: Addr must be set before opening the remote backend")
+	}
+	creds := insecure.NewCredentials()
+	if UseTLS {
+		creds = credentials.NewTLS(&tls.Config{})
+	}
+	conn, err := grpc.NewClient(Addr,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(pb.JSONCodec{})),
+	)
+	if err != nil {
+		return fmt.Errorf("remotedb: failed to dial %s: %w", Addr, err)
+	}
+	r.conn = conn
+	r.client = pb.NewRemoteDBClient(conn)
+	return nil
+}
+
+func (r *remoteKV) Seed(n int) ([][]byte, error) {
+	ops := make([]*pb.Op, n)
+	keys := make([][]byte, n)
+	for i := range ops {
+		k, v := backend.RandomKV()
+		ops[i] = &pb.Op{Kind: pb.Op_SET, Key: k, Value: v}
+		keys[i] = k
+	}
+	if _, err := r.client.Batch(context.Background(), &pb.BatchRequest{Ops: ops}); err != nil {
+		return nil, fmt.Errorf("remotedb: failed to seed: %w", err)
+	}
+	return keys, nil
+}
+
+func (r *remoteKV) Scan(fn func(k, v []byte) bool) error {
+	return r.iterate(&pb.IteratorRequest{}, fn)
+}
+
+func (r *remoteKV) ScanRange(seek []byte, n int, fn func(k, v []byte) bool) error {
+	// A zero limit means "no limit" on the wire, so n <= 0 is handled here.
+	if n <= 0 {
+		return nil
+	}
+	return r.iterate(&pb.IteratorRequest{Seek: seek, Limit: int64(n)}, fn)
+}
+
+func (r *remoteKV) iterate(req *pb.IteratorRequest, fn func(k, v []byte) bool) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := r.client.Iterator(ctx, req)
+	if err != nil {
+		return fmt.Errorf("remotedb: failed to open iterator: %w", err)
+	}
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("remotedb: iterator recv: %w", err)
+		}
+		if !fn(resp.Key, resp.Value) {
+			return nil
+		}
+	}
+}
+
+func (r *remoteKV) Get(k []byte) ([]byte, bool, error) {
+	resp, err := r.client.Get(context.Background(), &pb.GetRequest{Key: k})
+	if err != nil {
+		return nil, false, fmt.Errorf("remotedb: failed to get: %w", err)
+	}
+	return resp.Value, resp.Found, nil
+}
+
+func (r *remoteKV) Put(k, v []byte) error {
+	_, err := r.client.Set(context.Background(), &pb.SetRequest{Key: k, Value: v})
+	if err != nil {
+		return fmt.Errorf("remotedb: failed to set: %w", err)
+	}
+	return nil
+}
+
+func (r *remoteKV) Delete(k []byte) error {
+	_, err := r.client.Delete(context.Background(), &pb.DeleteRequest{Key: k})
+	if err != nil {
+		return fmt.Errorf("remotedb: failed to delete: %w", err)
+	}
+	return nil
+}
+
+func (r *remoteKV) Batch(ops []backend.BatchOp) error {
+	pbOps := make([]*pb.Op, len(ops))
+	for i, op := range ops {
+		pbOps[i] = &pb.Op{Kind: toPBKind(op.Kind), Key: op.Key, Value: op.Value}
+	}
+	if _, err := r.client.Batch(context.Background(), &pb.BatchRequest{Ops: pbOps}); err != nil {
+		return fmt.Errorf("remotedb: failed to batch: %w", err)
+	}
+	return nil
+}
+
+func toPBKind(k backend.OpKind) pb.Op_Kind {
+	if k == backend.OpDelete {
+		return pb.Op_DELETE
+	}
+	return pb.Op_SET
+}
+
+func (r *remoteKV) Close() error {
+	return r.conn.Close()
+}
@@ -2,65 +2,73 @@ package main
 
 import (
 	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
 	"os"
-	"path"
 	"runtime"
-	"strconv"
 	"sync"
 	"time"
 
-	"github.com/boltdb/bolt"
+	"github.com/five-vee/db-cmp/backend"
+	_ "github.com/five-vee/db-cmp/backend/badger"
+	_ "github.com/five-vee/db-cmp/backend/boltdb"
+	_ "github.com/five-vee/db-cmp/backend/leveldb"
+	"github.com/five-vee/db-cmp/backend/remotedb"
+	"github.com/five-vee/db-cmp/internal/latency"
+	"github.com/five-vee/db-cmp/internal/workload"
 )
 
-const (
-	bucketName     = "MyBucket"
-	maxKeyLength   = 1000
-	maxValueLength = 1000
-)
+const defaultMixSpec = "reads:70,scans:10,writes:20"
 
 func main() {
-	items := 1000
-	threads := 1
-	iters := 1000
-	backgroundWriter := true
-	if len(os.Args) == 1 {
-	} else if len(os.Args) == 5 {
-		var err error
-		if items, err = strconv.Atoi(os.Args[1]); err != nil {
-			log.Fatalf("items is not an integer: %v", err)
-		}
-		if threads, err = strconv.Atoi(os.Args[2]); err != nil {
-			log.Fatalf("threads is not an integer: %v", err)
-		}
-		if iters, err = strconv.Atoi(os.Args[3]); err != nil {
-			log.Fatalf("iters is not an integer: %v", err)
-		}
-		if backgroundWriter, err = strconv.ParseBool(os.Args[4]); err != nil {
-			log.Fatalf("backgroundWriter is not an integer: %v", err)
-		}
-	} else {
-		fmt.Printf("Usage: %s <items> <threads> <iters> <backgroundWriter>\n", os.Args[0])
-		os.Exit(1) // Exit with a non-zero status code to indicate an error
-	}
+	backendName := flag.String("backend", "bolt", fmt.Sprintf("KV backend to benchmark (one of %v)", backend.Names()))
+	addr := flag.String("addr", "", "address of a db-cmp-server to dial; only used when -backend=remote")
+	tlsFlag := flag.Bool("tls", false, "dial -addr over TLS; only used when -backend=remote")
+	items := flag.Int("items", 1000, "number of key-value pairs to seed before benchmarking")
+	threads := flag.Int("threads", 1, "number of concurrent worker goroutines")
+	iters := flag.Int("iters", 1000, "operations per worker goroutine; ignored if -duration is set")
+	duration := flag.Duration("duration", 0, "if set, run each worker for this long instead of a fixed number of iterations")
+	bgWriter := flag.Bool("bg-writer", true, "run a background writer goroutine to contend with the workload")
+	dir := flag.String("dir", "", "directory to create the benchmark's temporary database in (default: OS temp dir)")
+	seed := flag.Int64("seed", 1, "seed for math/rand, so seeded data and workload draws are reproducible across runs")
+	mixSpec := flag.String("workload", defaultMixSpec, "op mix, e.g. \"reads:70,scans:10,writes:20\"")
+	keyDist := flag.String("keydist", "uniform", "key distribution for reads/scans/deletes: uniform, zipf, or sequential")
+	opSize := flag.Int("opsize", 0, "value size in bytes for write ops (0 = random size, matching seeded data)")
+	scanSize := flag.Int("scansize", 100, "number of key-value pairs read by each scan op")
+	warmup := flag.String("warmup", "scan", "cache warm-up before timing begins: none, scan, or mmap-prefault")
+	flag.Parse()
 
-	elapsed := benchmarkReaders(items, threads, iters, backgroundWriter)
-	fmt.Printf("items: %d, threads: %d, iters: %d, backgroundWriter: %t, elapsed: %dus\n",
-		items, threads, iters, backgroundWriter, elapsed.Microseconds())
-	fmt.Printf("Avg latency per item: %.3fus\n", float64(elapsed.Microseconds())/float64(iters*items))
-}
+	remotedb.Addr = *addr
+	remotedb.UseTLS = *tlsFlag
+	rand.Seed(*seed)
 
-// randomAlphanumericString generates a random string of the specified length
-// containing only alphanumeric characters.
-func randomAlphanumericString(length int) string {
-	const alphanumericCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-	b := make([]byte, length)
-	for i := range b {
-		b[i] = alphanumericCharset[rand.Intn(len(alphanumericCharset))]
+	mix, err := workload.ParseMix(*mixSpec)
+	if err != nil {
+		log.Fatalf("invalid -workload: %v", err)
+	}
+
+	cfg := workload.Config{
+		Mix:      mix,
+		KeyDist:  *keyDist,
+		OpSize:   *opSize,
+		ScanSize: *scanSize,
+		Seed:     *seed,
+		Duration: *duration,
+	}
+	warmupElapsed, elapsed, stats := benchmarkWorkload(*backendName, *dir, *items, *threads, *iters, *bgWriter, *warmup, cfg)
+	fmt.Printf("backend: %s, items: %d, threads: %d, iters: %d, duration: %s, bg-writer: %t, warmup: %s (%s), elapsed: %s\n",
+		*backendName, *items, *threads, *iters, *duration, *bgWriter, *warmup, warmupElapsed, elapsed)
+	hists := make([]*latency.Histogram, 0, 3)
+	for _, op := range []workload.OpType{workload.OpRead, workload.OpScan, workload.OpWrite} {
+		s := stats[op]
+		fmt.Printf("  %-7s ops=%-8d throughput=%.1f ops/s mean_latency=%s\n",
+			op, s.Count(), s.Throughput(elapsed), s.Mean())
+		hists = append(hists, s.Histogram)
 	}
-	return string(b)
+	fmt.Println("latency (all ops):")
+	fmt.Print(latency.Merge(hists...).Report())
 }
 
 // mustFileExists checks if a file or directory exists at the given path.
@@ -77,137 +85,135 @@ func mustFileExists(path string) bool {
 	return false // unreachable
 }
 
-// mustRandomTemporaryFile creates a temporary file with a random name.
-// The file is created in the directory specified by the TMPDIR environment variable.
-// If TMPDIR is not set, the program will exit with an error.
-// The file name will be a random alphanumeric string of the specified length.
-// The function will retry generating a file name until a non-existent file is found.
-// It returns the path to the created file.
-func mustRandomTemporaryFile(fileNameLength int) string {
-	tmpDir, ok := os.LookupEnv("TMPDIR")
-	if !ok {
-		log.Fatalf("This benchmark can only be run on Mac OS X (no $TMPDIR found).")
-	}
-	tmpPath := path.Join(tmpDir, randomAlphanumericString(fileNameLength))
-	for mustFileExists(tmpPath) {
-		tmpPath = path.Join(tmpDir, randomAlphanumericString(fileNameLength))
+// mustRandomTemporaryPath reserves a unique path for a benchmark
+// database, under dir (or the OS default temp directory if dir is
+// empty). It returns the path without leaving anything on disk at it,
+// since backends differ on whether they expect a file (BoltDB) or a
+// directory (LevelDB, Badger) at that path.
+func mustRandomTemporaryPath(dir string) string {
+	f, err := os.CreateTemp(dir, "db-cmp-*")
+	if err != nil {
+		log.Fatalf("failed to reserve a temporary path: %v", err)
+	}
+	tmpPath := f.Name()
+	if err := f.Close(); err != nil {
+		log.Fatalf("failed to close temporary file: %v", err)
+	}
+	if err := os.Remove(tmpPath); err != nil {
+		log.Fatalf("failed to remove temporary file placeholder: %v", err)
 	}
 	return tmpPath
 }
 
-// tryRemoveIfExists attempts to remove the file if it exists.
-func tryRemoveIfExists(filePath string) {
-	if !mustFileExists(filePath) {
+// tryRemoveIfExists attempts to remove the file or directory if it exists.
+func tryRemoveIfExists(path string) {
+	if !mustFileExists(path) {
 		return
 	}
-	if err := os.Remove(filePath); err != nil {
-		log.Printf("error removing file: %v", err)
+	if err := os.RemoveAll(path); err != nil {
+		log.Printf("error removing %s: %v", path, err)
 	}
 }
 
-// setupDB creates a new BoltDB database for testing.
-// The cleanup function should be deferred immediately to close the database
-// and remove the temporary file.
-// If any error occurs during database creation, the program will exit with a
-// fatal error.
-func setupDB() (db *bolt.DB, cleanup func()) {
-	// Setup temporary file and open database.
-	tmpPath := mustRandomTemporaryFile(10)
-	db, err := bolt.Open(tmpPath, 0600, &bolt.Options{Timeout: 1 * time.Second})
+// setupDB opens the named backend at a fresh temporary path under dir
+// for testing. The cleanup function should be deferred immediately to
+// close the database and remove the temporary path. If any error occurs
+// during database creation, the program will exit with a fatal error.
+func setupDB(backendName, dir string) (db backend.KV, cleanup func()) {
+	db, err := backend.New(backendName)
 	if err != nil {
+		log.Fatalf("failed to construct backend: %v", err)
+	}
+	tmpPath := mustRandomTemporaryPath(dir)
+	if err := db.Open(tmpPath); err != nil {
 		tryRemoveIfExists(tmpPath)
-		log.Fatalf("failed to open bolt DB: %v", err)
+		log.Fatalf("failed to open %s DB: %v", backendName, err)
 	}
-	// b.Logf("Opened BoltDB at temporary file: %s\n", tmpPath)
 	cleanup = func() {
-		tryRemoveIfExists(tmpPath) // b is captured from setupDB's scope
 		db.Close()
+		tryRemoveIfExists(tmpPath)
 	}
 	return db, cleanup
 }
 
-// mustSeedDB seeds the database with a specified number of key-value pairs.
-// It creates a bucket and inserts random alphanumeric strings as keys and
-// values.
-func mustSeedDB(db *bolt.DB, n int) {
-	err := db.Batch(func(tx *bolt.Tx) error {
-		b, err := tx.CreateBucket([]byte(bucketName))
-		if err != nil {
-			return fmt.Errorf("failed to create bucket: %w", err)
-		}
-		for i := 0; i < n; i++ {
-			keyLen := rand.Intn(maxKeyLength) + 1
-			valLen := rand.Intn(maxValueLength) + 1
-			key := []byte(randomAlphanumericString(keyLen))
-			val := []byte(randomAlphanumericString(valLen))
-			if err := b.Put(key, val); err != nil {
-				return fmt.Errorf("failed to Put key i=%d: %w", i, err)
-			}
-		}
-		return nil
-	})
+// mustSeedDB seeds the database with a specified number of key-value
+// pairs and returns the keys that were written.
+func mustSeedDB(db backend.KV, n int) [][]byte {
+	keys, err := db.Seed(n)
 	if err != nil {
 		log.Fatalf("Failed to seed DB: %v", err)
 	}
+	return keys
 }
 
-func benchmarkReaders(items, threads, iters int, backgroundWriter bool) time.Duration {
+// doWarmup pages db's data into the OS cache before timing begins, so
+// the first iteration of a run isn't dominated by cold-cache page
+// faults. It returns how long the warm-up itself took.
+func doWarmup(db backend.KV, mode string) time.Duration {
+	start := time.Now()
+	switch mode {
+	case "none":
+	case "scan":
+		if err := db.Scan(func(k, v []byte) bool { return true }); err != nil {
+			log.Fatalf("warmup scan failed: %v", err)
+		}
+	case "mmap-prefault":
+		p, ok := db.(backend.Prefaulter)
+		if !ok {
+			log.Fatalf("-warmup=mmap-prefault is not supported by this backend")
+		}
+		if err := p.Prefault(); err != nil {
+			log.Fatalf("warmup prefault failed: %v", err)
+		}
+	default:
+		log.Fatalf("unknown -warmup mode %q (want none, scan, or mmap-prefault)", mode)
+	}
+	return time.Since(start)
+}
+
+// benchmarkWorkload seeds items key-value pairs, warms the cache per
+// warmupMode, then drives threads goroutines through cfg against the
+// seeded keys. It returns the warm-up duration, the wall-clock elapsed
+// time of the timed run, and per-op-type stats.
+func benchmarkWorkload(backendName, dir string, items, threads, iters int, backgroundWriter bool, warmupMode string, cfg workload.Config) (time.Duration, time.Duration, map[workload.OpType]*workload.Stats) {
 	// Setup.
-	db, cleanup := setupDB()
+	db, cleanup := setupDB(backendName, dir)
 	defer cleanup()
-	mustSeedDB(db, items)
+	keys := mustSeedDB(db, items)
+	warmupElapsed := doWarmup(db, warmupMode)
 
-	// Optionally start background writer.
+	// Optionally start a background writer, contending with the
+	// benchmark workers the way mvcc/lock contention would in practice.
+	// It must be fully stopped (not just signaled) before cleanup's
+	// db.Close() runs, or a Put can race a close of the underlying store.
 	stop := make(chan struct{})
+	var wg sync.WaitGroup
 	if backgroundWriter {
+		wg.Add(1)
 		go func() {
+			defer wg.Done()
 			runtime.LockOSThread()
-			// defer runtime.UnlockOSThread()
-			err := db.Batch(func(tx *bolt.Tx) error {
-				b := tx.Bucket([]byte(bucketName))
-				for {
-					select {
-					case <-stop:
-						return nil
-					default:
-						if err := b.Put([]byte("dummy_key"), []byte("dummy_val")); err != nil {
-							return err
-						}
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					if err := db.Put([]byte("dummy_key"), []byte("dummy_val")); err != nil {
+						panic(fmt.Errorf("failed to Put into DB: %w", err))
 					}
 				}
-			})
-			if err != nil {
-				panic(fmt.Errorf("failed to Batch into DB: %w", err))
 			}
 		}()
 	}
 
 	// Run benchmark load.
 	start := time.Now()
-	var wg sync.WaitGroup
-	wg.Add(threads)
-	for range threads {
-		go func() {
-			defer wg.Done()
-			runtime.LockOSThread()
-			// defer runtime.UnlockOSThread()
-			for range iters {
-				err := db.View(func(tx *bolt.Tx) error {
-					c := tx.Bucket([]byte(bucketName)).Cursor()
-					for k, v := c.First(); k != nil; k, v = c.Next() {
-						_ = k
-						_ = v
-					}
-					return nil
-				})
-				if err != nil {
-					panic(fmt.Errorf("failed to view into DB: %w", err))
-				}
-			}
-		}()
-	}
-	wg.Wait()
-	elapsed := time.Now().Sub(start)
+	stats, err := workload.Run(db, keys, threads, iters, cfg)
+	elapsed := time.Since(start)
 	close(stop)
-	return elapsed
+	wg.Wait()
+	if err != nil {
+		log.Fatalf("workload run failed: %v", err)
+	}
+	return warmupElapsed, elapsed, stats
 }
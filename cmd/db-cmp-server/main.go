@@ -0,0 +1,140 @@
+// Command db-cmp-server wraps a local backend.KV implementation (bolt,
+// leveldb, badger) and serves it over gRPC so the db-cmp benchmark
+// harness can drive it via the "remote" backend.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/five-vee/db-cmp/backend"
+	_ "github.com/five-vee/db-cmp/backend/badger"
+	_ "github.com/five-vee/db-cmp/backend/boltdb"
+	_ "github.com/five-vee/db-cmp/backend/leveldb"
+	pb "github.com/five-vee/db-cmp/proto/remotedb"
+)
+
+func main() {
+	addr := flag.String("addr", ":7777", "address to listen on")
+	backendName := flag.String("backend", "bolt", fmt.Sprintf("local backend to serve (one of %v)", backend.Names()))
+	dir := flag.String("dir", "", "path to the underlying database (created if it doesn't exist)")
+	certFile := flag.String("cert", "", "TLS certificate file; if unset, the server listens without TLS")
+	keyFile := flag.String("key", "", "TLS key file; required if -cert is set")
+	flag.Parse()
+
+	if *dir == "" {
+		log.Fatalf("-dir is required")
+	}
+
+	db, err := backend.New(*backendName)
+	if err != nil {
+		log.Fatalf("failed to construct backend %q: %v", *backendName, err)
+	}
+	if err := db.Open(*dir); err != nil {
+		log.Fatalf("failed to open backend %q at %q: %v", *backendName, *dir, err)
+	}
+	defer db.Close()
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("failed to listen on %s: %v", *addr, err)
+	}
+
+	var opts []grpc.ServerOption
+	if *certFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(*certFile, *keyFile)
+		if err != nil {
+			log.Fatalf("failed to load TLS credentials: %v", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	} else {
+		opts = append(opts, grpc.Creds(insecure.NewCredentials()))
+	}
+	opts = append(opts, grpc.ForceServerCodec(pb.JSONCodec{}))
+
+	server := grpc.NewServer(opts...)
+	pb.RegisterRemoteDBServer(server, &remoteDBServer{db: db})
+
+	log.Printf("db-cmp-server serving backend=%s dir=%s on %s", *backendName, *dir, *addr)
+	if err := server.Serve(lis); err != nil {
+		log.Fatalf("server exited: %v", err)
+	}
+}
+
+// remoteDBServer adapts a backend.KV to the RemoteDB gRPC service.
+type remoteDBServer struct {
+	pb.UnimplementedRemoteDBServer
+	db backend.KV
+}
+
+func (s *remoteDBServer) Get(ctx context.Context, req *pb.GetRequest) (*pb.GetResponse, error) {
+	v, found, err := s.db.Get(req.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetResponse{Value: v, Found: found}, nil
+}
+
+func (s *remoteDBServer) Set(ctx context.Context, req *pb.SetRequest) (*pb.SetResponse, error) {
+	if err := s.db.Put(req.Key, req.Value); err != nil {
+		return nil, err
+	}
+	return &pb.SetResponse{}, nil
+}
+
+func (s *remoteDBServer) Delete(ctx context.Context, req *pb.DeleteRequest) (*pb.DeleteResponse, error) {
+	if err := s.db.Delete(req.Key); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteResponse{}, nil
+}
+
+func (s *remoteDBServer) Iterator(req *pb.IteratorRequest, stream pb.RemoteDB_IteratorServer) error {
+	var sendErr error
+	send := func(k, v []byte) bool {
+		if err := stream.Send(&pb.IteratorResponse{Key: k, Value: v}); err != nil {
+			sendErr = err
+			return false
+		}
+		return true
+	}
+	var err error
+	switch {
+	case req.Limit > 0:
+		err = s.db.ScanRange(req.Seek, int(req.Limit), send)
+	case len(req.Seek) > 0:
+		err = s.db.ScanRange(req.Seek, math.MaxInt, send)
+	default:
+		err = s.db.Scan(send)
+	}
+	if sendErr != nil {
+		return sendErr
+	}
+	return err
+}
+
+func (s *remoteDBServer) Batch(ctx context.Context, req *pb.BatchRequest) (*pb.BatchResponse, error) {
+	ops := make([]backend.BatchOp, len(req.Ops))
+	for i, op := range req.Ops {
+		ops[i] = backend.BatchOp{Kind: fromPBKind(op.Kind), Key: op.Key, Value: op.Value}
+	}
+	if err := s.db.Batch(ops); err != nil {
+		return nil, err
+	}
+	return &pb.BatchResponse{}, nil
+}
+
+func fromPBKind(k pb.Op_Kind) backend.OpKind {
+	if k == pb.Op_DELETE {
+		return backend.OpDelete
+	}
+	return backend.OpSet
+}
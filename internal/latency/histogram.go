@@ -0,0 +1,175 @@
+// Package latency provides a log-linear latency histogram, in the style
+// of HdrHistogram, for recording the wall-clock duration of individual
+// operations without the coordination cost of a shared counter.
+//
+// The intended usage is one Histogram per goroutine, each recording its
+// own operations lock-free, merged into a single Histogram once all
+// goroutines have finished.
+package latency
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	// minTrackableNanos and maxTrackableNanos bound the range of
+	// durations the histogram can represent; values outside the range
+	// are clamped to the nearest bound.
+	minTrackableNanos = int64(time.Microsecond)
+	maxTrackableNanos = int64(60 * time.Second)
+
+	// significantDigits sets the relative precision within each decade:
+	// 3 digits means any recorded value is rounded down to three
+	// significant digits, i.e. to within 1% of its true value (~0.1% near
+	// the top of a decade).
+	significantDigits   = 3
+	subBucketsPerDecade = 900 // 9 * 10^(significantDigits-1)
+)
+
+var (
+	minDecade  = decadeOf(minTrackableNanos)
+	maxDecade  = decadeOf(maxTrackableNanos)
+	numDecades = maxDecade - minDecade + 1
+)
+
+func decadeOf(v int64) int {
+	return int(math.Floor(math.Log10(float64(v))))
+}
+
+// subBucketWidth returns the width, in nanoseconds, of each sub-bucket
+// within the given decade.
+func subBucketWidth(decade int) int64 {
+	return int64(math.Pow10(decade)) / 100
+}
+
+// Histogram is a log-linear histogram of durations between
+// minTrackableNanos and maxTrackableNanos.
+type Histogram struct {
+	counts []int64
+	count  int64
+	sum    int64
+	max    int64
+}
+
+// New returns an empty Histogram.
+func New() *Histogram {
+	return &Histogram{counts: make([]int64, numDecades*subBucketsPerDecade)}
+}
+
+// Record adds d to the histogram, clamping it to the trackable range.
+func (h *Histogram) Record(d time.Duration) {
+	v := int64(d)
+	if v < minTrackableNanos {
+		v = minTrackableNanos
+	}
+	if v > maxTrackableNanos {
+		v = maxTrackableNanos
+	}
+	h.counts[bucketIndex(v)]++
+	h.count++
+	h.sum += v
+	if v > h.max {
+		h.max = v
+	}
+}
+
+func bucketIndex(v int64) int {
+	decade := decadeOf(v)
+	if decade < minDecade {
+		decade = minDecade
+	}
+	if decade > maxDecade {
+		decade = maxDecade
+	}
+	decadeStart := int64(math.Pow10(decade))
+	pos := (v - decadeStart) / subBucketWidth(decade)
+	if pos >= subBucketsPerDecade {
+		pos = subBucketsPerDecade - 1
+	}
+	return (decade-minDecade)*subBucketsPerDecade + int(pos)
+}
+
+// bucketLowerBound returns the smallest value representable by bucket
+// idx, used as that bucket's representative value when reporting
+// percentiles.
+func bucketLowerBound(idx int) int64 {
+	decade := minDecade + idx/subBucketsPerDecade
+	pos := idx % subBucketsPerDecade
+	return int64(math.Pow10(decade)) + int64(pos)*subBucketWidth(decade)
+}
+
+// Merge returns a new Histogram combining the counts of all of hs.
+func Merge(hs ...*Histogram) *Histogram {
+	merged := New()
+	for _, h := range hs {
+		for i, c := range h.counts {
+			merged.counts[i] += c
+		}
+		merged.count += h.count
+		merged.sum += h.sum
+		if h.max > merged.max {
+			merged.max = h.max
+		}
+	}
+	return merged
+}
+
+// Count returns the number of recorded values.
+func (h *Histogram) Count() int64 { return h.count }
+
+// Mean returns the arithmetic mean of all recorded values.
+func (h *Histogram) Mean() time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	return time.Duration(h.sum / h.count)
+}
+
+// Max returns the largest recorded value.
+func (h *Histogram) Max() time.Duration { return time.Duration(h.max) }
+
+// ValueAtPercentile returns the value at or below which p percent
+// (0 < p <= 100) of recorded values fall.
+func (h *Histogram) ValueAtPercentile(p float64) time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	target := int64(math.Ceil(p / 100 * float64(h.count)))
+	var cum int64
+	for i, c := range h.counts {
+		cum += c
+		if cum >= target {
+			return time.Duration(bucketLowerBound(i))
+		}
+	}
+	return time.Duration(h.max)
+}
+
+// Report renders p50/p90/p99/p99.9/max plus a compact ASCII CDF.
+func (h *Histogram) Report() string {
+	var b strings.Builder
+	percentiles := []float64{50, 90, 99, 99.9}
+	for _, p := range percentiles {
+		fmt.Fprintf(&b, "p%-5g %s\n", p, h.ValueAtPercentile(p))
+	}
+	fmt.Fprintf(&b, "max   %s\n", h.Max())
+	b.WriteString(h.cdf())
+	return b.String()
+}
+
+// cdf renders a compact ASCII cumulative distribution, one line per
+// decile.
+func (h *Histogram) cdf() string {
+	var b strings.Builder
+	const width = 40
+	for i := 1; i <= 10; i++ {
+		p := float64(i) * 10
+		v := h.ValueAtPercentile(p)
+		filled := int(p / 100 * width)
+		fmt.Fprintf(&b, "p%-4g [%s%s] %s\n", p, strings.Repeat("#", filled), strings.Repeat(" ", width-filled), v)
+	}
+	return b.String()
+}
@@ -0,0 +1,102 @@
+package latency
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHistogram_Empty(t *testing.T) {
+	h := New()
+	if got := h.Count(); got != 0 {
+		t.Errorf("Count() = %d, want 0", got)
+	}
+	if got := h.Mean(); got != 0 {
+		t.Errorf("Mean() = %s, want 0", got)
+	}
+	if got := h.ValueAtPercentile(99); got != 0 {
+		t.Errorf("ValueAtPercentile(99) = %s, want 0", got)
+	}
+}
+
+func TestHistogram_RecordAndCount(t *testing.T) {
+	h := New()
+	durations := []time.Duration{
+		10 * time.Microsecond,
+		1 * time.Millisecond,
+		500 * time.Millisecond,
+	}
+	for _, d := range durations {
+		h.Record(d)
+	}
+	if got, want := h.Count(), int64(len(durations)); got != want {
+		t.Errorf("Count() = %d, want %d", got, want)
+	}
+	if got, want := h.Max(), 500*time.Millisecond; got != want {
+		t.Errorf("Max() = %s, want %s", got, want)
+	}
+}
+
+func TestHistogram_RecordClampsOutOfRange(t *testing.T) {
+	h := New()
+	h.Record(0)         // below minTrackableNanos
+	h.Record(time.Hour) // above maxTrackableNanos
+	if got, want := h.Count(), int64(2); got != want {
+		t.Fatalf("Count() = %d, want %d", got, want)
+	}
+	if got := h.Max(); got > 60*time.Second {
+		t.Errorf("Max() = %s, want <= 60s (clamped)", got)
+	}
+}
+
+func TestHistogram_ValueAtPercentile(t *testing.T) {
+	h := New()
+	// 99 fast ops and 1 slow outlier: p50 should land near the fast
+	// cluster, while p99.9 (and max) reflect the outlier.
+	for i := 0; i < 99; i++ {
+		h.Record(100 * time.Microsecond)
+	}
+	h.Record(50 * time.Millisecond)
+
+	p50 := h.ValueAtPercentile(50)
+	if p50 < 90*time.Microsecond || p50 > 110*time.Microsecond {
+		t.Errorf("ValueAtPercentile(50) = %s, want ~100µs", p50)
+	}
+	p999 := h.ValueAtPercentile(99.9)
+	if p999 < 40*time.Millisecond {
+		t.Errorf("ValueAtPercentile(99.9) = %s, want to reflect the 50ms outlier", p999)
+	}
+}
+
+func TestHistogram_ValueAtPercentileMonotonic(t *testing.T) {
+	h := New()
+	for i := 1; i <= 1000; i++ {
+		h.Record(time.Duration(i) * time.Microsecond)
+	}
+	prev := time.Duration(0)
+	for _, p := range []float64{10, 25, 50, 75, 90, 99, 99.9} {
+		v := h.ValueAtPercentile(p)
+		if v < prev {
+			t.Errorf("ValueAtPercentile(%g) = %s, want >= previous percentile's %s", p, v, prev)
+		}
+		prev = v
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a, b := New(), New()
+	a.Record(1 * time.Millisecond)
+	a.Record(2 * time.Millisecond)
+	b.Record(3 * time.Millisecond)
+
+	merged := Merge(a, b)
+	if got, want := merged.Count(), int64(3); got != want {
+		t.Errorf("Count() = %d, want %d", got, want)
+	}
+	if got, want := merged.Max(), 3*time.Millisecond; got != want {
+		t.Errorf("Max() = %s, want %s", got, want)
+	}
+	wantMean := time.Duration((int64(1+2+3) * int64(time.Millisecond)) / 3)
+	if got := merged.Mean(); got != wantMean {
+		t.Errorf("Mean() = %s, want %s", got, wantMean)
+	}
+}
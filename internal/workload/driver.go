@@ -0,0 +1,110 @@
+package workload
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/five-vee/db-cmp/backend"
+)
+
+// Config parametrizes a workload run.
+type Config struct {
+	// Mix is the relative weight of each op type.
+	Mix Mix
+	// KeyDist names the key distribution to draw read/scan/delete
+	// targets from: "uniform", "zipf", or "sequential".
+	KeyDist string
+	// OpSize is the value size, in bytes, used for writes. 0 means use
+	// backend.RandomKV's default random sizing.
+	OpSize int
+	// ScanSize is the number of key-value pairs read by each scan op.
+	ScanSize int
+	// Seed derives each worker's random source, so a run is
+	// reproducible given the same keyspace and Config. Worker id i uses
+	// Seed+int64(i).
+	Seed int64
+	// Duration, if non-zero, runs each worker for this long instead of
+	// a fixed number of iterations.
+	Duration time.Duration
+}
+
+// Run drives db with threads goroutines against the keyspace in keys,
+// each performing iters operations drawn from cfg.Mix (or running for
+// cfg.Duration, if set). It returns per-op-type stats merged across all
+// workers.
+func Run(db backend.KV, keys [][]byte, threads, iters int, cfg Config) (map[OpType]*Stats, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("workload: no keys to operate on")
+	}
+	pick := newPicker(cfg.Mix)
+
+	var wg sync.WaitGroup
+	results := make([]map[OpType]*Stats, threads)
+	errs := make([]error, threads)
+	wg.Add(threads)
+	for i := 0; i < threads; i++ {
+		go func(id int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(cfg.Seed + int64(id)))
+			dist, err := NewKeyDist(cfg.KeyDist, len(keys), r)
+			if err != nil {
+				errs[id] = err
+				return
+			}
+			results[id], errs[id] = runWorker(db, keys, dist, pick, r, iters, cfg)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return mergeStatsByOp(results), nil
+}
+
+func runWorker(db backend.KV, keys [][]byte, dist KeyDist, pick *picker, r *rand.Rand, iters int, cfg Config) (map[OpType]*Stats, error) {
+	stats := newStatsByOp()
+	deadline := time.Time{}
+	if cfg.Duration > 0 {
+		deadline = time.Now().Add(cfg.Duration)
+	}
+	for n := 0; cfg.Duration > 0 || n < iters; n++ {
+		if cfg.Duration > 0 && time.Now().After(deadline) {
+			break
+		}
+		op := pick.pick(r)
+		start := time.Now()
+		var err error
+		switch op {
+		case OpRead:
+			_, _, err = db.Get(keys[dist.Next()])
+		case OpScan:
+			err = db.ScanRange(keys[dist.Next()], cfg.ScanSize, func(k, v []byte) bool { return true })
+		case OpWrite:
+			err = doWrite(db, keys, dist, r, cfg.OpSize)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("workload: %s op failed: %w", op, err)
+		}
+		stats[op].Record(time.Since(start))
+	}
+	return stats, nil
+}
+
+// doWrite issues either a Put of a fresh random key or a Delete of an
+// existing one, so that the "writes" op type exercises both sides of
+// db.Update the way a mixed workload would.
+func doWrite(db backend.KV, keys [][]byte, dist KeyDist, r *rand.Rand, opSize int) error {
+	if r.Intn(2) == 0 {
+		k, v := backend.RandomKVFrom(r)
+		if opSize > 0 {
+			v = []byte(backend.RandomAlphanumericFrom(r, opSize))
+		}
+		return db.Put(k, v)
+	}
+	return db.Delete(keys[dist.Next()])
+}
@@ -0,0 +1,55 @@
+package workload
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// KeyDist draws the index of a key to operate on next, out of a
+// keyspace of a fixed size.
+type KeyDist interface {
+	Next() int
+}
+
+// NewKeyDist constructs the KeyDist registered under name, drawing from
+// a keyspace of size n using r as its source of randomness.
+func NewKeyDist(name string, n int, r *rand.Rand) (KeyDist, error) {
+	switch name {
+	case "uniform":
+		return &uniformDist{r: r, n: n}, nil
+	case "sequential":
+		return &sequentialDist{n: n}, nil
+	case "zipf":
+		// s > 1 and v == 1 bias heavily towards low indices, approximating
+		// the hot-key skew YCSB's "zipfian" distribution produces.
+		return &zipfDist{z: rand.NewZipf(r, 1.1, 1, uint64(n-1))}, nil
+	default:
+		return nil, fmt.Errorf("workload: unknown key distribution %q (want one of uniform, zipf, sequential)", name)
+	}
+}
+
+type uniformDist struct {
+	r *rand.Rand
+	n int
+}
+
+func (d *uniformDist) Next() int { return d.r.Intn(d.n) }
+
+// sequentialDist walks the keyspace in order, wrapping around at the
+// end. It is not safe for concurrent use; each worker should own one.
+type sequentialDist struct {
+	n   int
+	cur int
+}
+
+func (d *sequentialDist) Next() int {
+	i := d.cur % d.n
+	d.cur++
+	return i
+}
+
+type zipfDist struct {
+	z *rand.Zipf
+}
+
+func (d *zipfDist) Next() int { return int(d.z.Uint64()) }
@@ -0,0 +1,57 @@
+package workload
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestNewKeyDist_UnknownName(t *testing.T) {
+	if _, err := NewKeyDist("bogus", 10, rand.New(rand.NewSource(1))); err == nil {
+		t.Fatal("NewKeyDist(\"bogus\", ...) succeeded, want error")
+	}
+}
+
+func TestNewKeyDist_InRange(t *testing.T) {
+	const n = 100
+	for _, name := range []string{"uniform", "sequential", "zipf"} {
+		t.Run(name, func(t *testing.T) {
+			d, err := NewKeyDist(name, n, rand.New(rand.NewSource(1)))
+			if err != nil {
+				t.Fatalf("NewKeyDist(%q, ...) failed: %v", name, err)
+			}
+			for i := 0; i < 1000; i++ {
+				if next := d.Next(); next < 0 || next >= n {
+					t.Fatalf("%s Next() = %d, want in [0, %d)", name, next, n)
+				}
+			}
+		})
+	}
+}
+
+func TestSequentialDist_WrapsAndIncrements(t *testing.T) {
+	d, err := NewKeyDist("sequential", 3, nil)
+	if err != nil {
+		t.Fatalf("NewKeyDist(\"sequential\", ...) failed: %v", err)
+	}
+	want := []int{0, 1, 2, 0, 1, 2}
+	for i, w := range want {
+		if got := d.Next(); got != w {
+			t.Fatalf("Next() #%d = %d, want %d", i, got, w)
+		}
+	}
+}
+
+func TestNewKeyDist_Deterministic(t *testing.T) {
+	for _, name := range []string{"uniform", "zipf"} {
+		t.Run(name, func(t *testing.T) {
+			d1, _ := NewKeyDist(name, 50, rand.New(rand.NewSource(42)))
+			d2, _ := NewKeyDist(name, 50, rand.New(rand.NewSource(42)))
+			for i := 0; i < 20; i++ {
+				a, b := d1.Next(), d2.Next()
+				if a != b {
+					t.Fatalf("%s draw #%d diverged: %d != %d", name, i, a, b)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Package workload implements a configurable, YCSB-style mixed
+// read/scan/write operation driver for benchmarking a backend.KV.
+package workload
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// OpType identifies a kind of operation in a workload Mix.
+type OpType string
+
+const (
+	OpRead  OpType = "reads"
+	OpScan  OpType = "scans"
+	OpWrite OpType = "writes"
+)
+
+// Mix is a set of relative weights for each OpType, e.g. the spec
+// "reads:70,scans:10,writes:20" parses to Mix{OpRead: 70, OpScan: 10,
+// OpWrite: 20}.
+type Mix map[OpType]int
+
+// ParseMix parses a spec like "reads:70,scans:10,writes:20" into a Mix.
+// Unknown op types and non-positive weights are rejected.
+func ParseMix(spec string) (Mix, error) {
+	mix := Mix{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, ":", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("workload: invalid mix entry %q (want name:weight)", part)
+		}
+		op := OpType(strings.TrimSpace(kv[0]))
+		switch op {
+		case OpRead, OpScan, OpWrite:
+		default:
+			return nil, fmt.Errorf("workload: unknown op type %q (want one of reads, scans, writes)", kv[0])
+		}
+		weight, err := strconv.Atoi(strings.TrimSpace(kv[1]))
+		if err != nil || weight <= 0 {
+			return nil, fmt.Errorf("workload: invalid weight for %q: %q", kv[0], kv[1])
+		}
+		mix[op] = weight
+	}
+	if len(mix) == 0 {
+		return nil, fmt.Errorf("workload: mix spec %q has no entries", spec)
+	}
+	return mix, nil
+}
+
+// picker draws a weighted-random OpType from a Mix.
+type picker struct {
+	ops    []OpType
+	cumSum []int
+	total  int
+}
+
+func newPicker(mix Mix) *picker {
+	ops := make([]OpType, 0, len(mix))
+	for op := range mix {
+		ops = append(ops, op)
+	}
+	// Sort so that pick() is deterministic for a given *rand.Rand stream.
+	sort.Slice(ops, func(i, j int) bool { return ops[i] < ops[j] })
+
+	p := &picker{ops: ops, cumSum: make([]int, len(ops))}
+	sum := 0
+	for i, op := range ops {
+		sum += mix[op]
+		p.cumSum[i] = sum
+	}
+	p.total = sum
+	return p
+}
+
+func (p *picker) pick(r *rand.Rand) OpType {
+	n := r.Intn(p.total)
+	for i, c := range p.cumSum {
+		if n < c {
+			return p.ops[i]
+		}
+	}
+	return p.ops[len(p.ops)-1]
+}
@@ -0,0 +1,77 @@
+package workload
+
+import "testing"
+
+func TestParseMix(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    Mix
+		wantErr bool
+	}{
+		{
+			name: "all op types",
+			spec: "reads:70,scans:10,writes:20",
+			want: Mix{OpRead: 70, OpScan: 10, OpWrite: 20},
+		},
+		{
+			name: "single entry",
+			spec: "writes:1",
+			want: Mix{OpWrite: 1},
+		},
+		{
+			name: "whitespace around entries",
+			spec: " reads:50 , writes:50 ",
+			want: Mix{OpRead: 50, OpWrite: 50},
+		},
+		{
+			name:    "empty spec",
+			spec:    "",
+			wantErr: true,
+		},
+		{
+			name:    "unknown op type",
+			spec:    "deletes:10",
+			wantErr: true,
+		},
+		{
+			name:    "missing weight",
+			spec:    "reads",
+			wantErr: true,
+		},
+		{
+			name:    "zero weight",
+			spec:    "reads:0",
+			wantErr: true,
+		},
+		{
+			name:    "negative weight",
+			spec:    "reads:-5",
+			wantErr: true,
+		},
+		{
+			name:    "non-numeric weight",
+			spec:    "reads:abc",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMix(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMix(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseMix(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+			for op, weight := range tt.want {
+				if got[op] != weight {
+					t.Errorf("ParseMix(%q)[%q] = %d, want %d", tt.spec, op, got[op], weight)
+				}
+			}
+		})
+	}
+}
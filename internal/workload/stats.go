@@ -0,0 +1,42 @@
+package workload
+
+import (
+	"time"
+
+	"github.com/five-vee/db-cmp/internal/latency"
+)
+
+// Stats is a per-op-type latency histogram, plus the throughput it
+// implies over a given wall-clock duration.
+type Stats struct {
+	*latency.Histogram
+}
+
+// Throughput returns the number of operations per second, given the
+// wall-clock duration the workload ran for.
+func (s *Stats) Throughput(elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Count()) / elapsed.Seconds()
+}
+
+func newStatsByOp() map[OpType]*Stats {
+	return map[OpType]*Stats{
+		OpRead:  {latency.New()},
+		OpScan:  {latency.New()},
+		OpWrite: {latency.New()},
+	}
+}
+
+func mergeStatsByOp(results []map[OpType]*Stats) map[OpType]*Stats {
+	merged := map[OpType]*Stats{}
+	for _, op := range []OpType{OpRead, OpScan, OpWrite} {
+		hists := make([]*latency.Histogram, 0, len(results))
+		for _, r := range results {
+			hists = append(hists, r[op].Histogram)
+		}
+		merged[op] = &Stats{latency.Merge(hists...)}
+	}
+	return merged
+}
@@ -0,0 +1,20 @@
+package remotedb
+
+import "encoding/json"
+
+// JSONCodec is a grpc/encoding.Codec that marshals this package's
+// message types as JSON instead of the binary protobuf wire format.
+// It exists because those types are hand-maintained plain structs, not
+// real protoc-gen-go output, so they don't implement proto.Message and
+// can't go through grpc's default "proto" codec.
+//
+// Callers must force this codec explicitly on both ends of the
+// connection, with grpc.ForceCodec (client) / grpc.ForceServerCodec
+// (server), since it isn't registered for codec negotiation.
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v any) ([]byte, error) { return json.Marshal(v) }
+
+func (JSONCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+func (JSONCodec) Name() string { return "json" }
@@ -0,0 +1,12 @@
+package remotedb
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func grpcUnimplemented(method string) error {
+	return status.Error(codes.Unimplemented, fmt.Sprintf("method %s not implemented", method))
+}
@@ -0,0 +1,58 @@
+// Package remotedb's message types mirror the schema in remotedb.proto.
+// They are hand-maintained plain Go structs, not real protoc-gen-go
+// output: they carry `protobuf:` tags purely as schema documentation,
+// and are put on the wire as JSON via the JSONCodec in codec.go rather
+// than the binary protobuf encoding, so they don't need to implement
+// proto.Message.
+package remotedb
+
+type Op_Kind int32
+
+const (
+	Op_SET    Op_Kind = 0
+	Op_DELETE Op_Kind = 1
+)
+
+type GetRequest struct {
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+type GetResponse struct {
+	Value []byte `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Found bool   `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"`
+}
+
+type SetRequest struct {
+	Key   []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+type SetResponse struct{}
+
+type DeleteRequest struct {
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+type DeleteResponse struct{}
+
+type IteratorRequest struct {
+	Seek  []byte `protobuf:"bytes,1,opt,name=seek,proto3" json:"seek,omitempty"`
+	Limit int64  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+type IteratorResponse struct {
+	Key   []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+type Op struct {
+	Kind  Op_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=remotedb.Op_Kind" json:"kind,omitempty"`
+	Key   []byte  `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value []byte  `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+type BatchRequest struct {
+	Ops []*Op `protobuf:"bytes,1,rep,name=ops,proto3" json:"ops,omitempty"`
+}
+
+type BatchResponse struct{}
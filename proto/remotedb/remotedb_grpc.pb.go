@@ -0,0 +1,236 @@
+// Client and server stubs for the RemoteDB service, hand-maintained to
+// match the service definition in remotedb.proto. They follow the shape
+// protoc-gen-go-grpc would produce, but callers must dial/serve with
+// JSONCodec (see codec.go) rather than relying on codec negotiation,
+// since the message types in remotedb.pb.go aren't real proto.Message
+// implementations.
+
+package remotedb
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	RemoteDB_Get_FullMethodName      = "/remotedb.RemoteDB/Get"
+	RemoteDB_Set_FullMethodName      = "/remotedb.RemoteDB/Set"
+	RemoteDB_Delete_FullMethodName   = "/remotedb.RemoteDB/Delete"
+	RemoteDB_Iterator_FullMethodName = "/remotedb.RemoteDB/Iterator"
+	RemoteDB_Batch_FullMethodName    = "/remotedb.RemoteDB/Batch"
+)
+
+// RemoteDBClient is the client API for RemoteDB service.
+type RemoteDBClient interface {
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	Iterator(ctx context.Context, in *IteratorRequest, opts ...grpc.CallOption) (RemoteDB_IteratorClient, error)
+	Batch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error)
+}
+
+type remoteDBClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRemoteDBClient(cc grpc.ClientConnInterface) RemoteDBClient {
+	return &remoteDBClient{cc}
+}
+
+func (c *remoteDBClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	out := new(GetResponse)
+	if err := c.cc.Invoke(ctx, RemoteDB_Get_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteDBClient) Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error) {
+	out := new(SetResponse)
+	if err := c.cc.Invoke(ctx, RemoteDB_Set_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteDBClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	if err := c.cc.Invoke(ctx, RemoteDB_Delete_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteDBClient) Iterator(ctx context.Context, in *IteratorRequest, opts ...grpc.CallOption) (RemoteDB_IteratorClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RemoteDB_ServiceDesc.Streams[0], RemoteDB_Iterator_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &remoteDBIteratorClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RemoteDB_IteratorClient interface {
+	Recv() (*IteratorResponse, error)
+	grpc.ClientStream
+}
+
+type remoteDBIteratorClient struct {
+	grpc.ClientStream
+}
+
+func (x *remoteDBIteratorClient) Recv() (*IteratorResponse, error) {
+	m := new(IteratorResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *remoteDBClient) Batch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error) {
+	out := new(BatchResponse)
+	if err := c.cc.Invoke(ctx, RemoteDB_Batch_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RemoteDBServer is the server API for RemoteDB service.
+type RemoteDBServer interface {
+	Get(context.Context, *GetRequest) (*GetResponse, error)
+	Set(context.Context, *SetRequest) (*SetResponse, error)
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	Iterator(*IteratorRequest, RemoteDB_IteratorServer) error
+	Batch(context.Context, *BatchRequest) (*BatchResponse, error)
+}
+
+// UnimplementedRemoteDBServer may be embedded to have forward compatible implementations.
+type UnimplementedRemoteDBServer struct{}
+
+func (UnimplementedRemoteDBServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
+	return nil, grpcUnimplemented("Get")
+}
+func (UnimplementedRemoteDBServer) Set(context.Context, *SetRequest) (*SetResponse, error) {
+	return nil, grpcUnimplemented("Set")
+}
+func (UnimplementedRemoteDBServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, grpcUnimplemented("Delete")
+}
+func (UnimplementedRemoteDBServer) Iterator(*IteratorRequest, RemoteDB_IteratorServer) error {
+	return grpcUnimplemented("Iterator")
+}
+func (UnimplementedRemoteDBServer) Batch(context.Context, *BatchRequest) (*BatchResponse, error) {
+	return nil, grpcUnimplemented("Batch")
+}
+
+type RemoteDB_IteratorServer interface {
+	Send(*IteratorResponse) error
+	grpc.ServerStream
+}
+
+type remoteDBIteratorServer struct {
+	grpc.ServerStream
+}
+
+func (x *remoteDBIteratorServer) Send(m *IteratorResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func RegisterRemoteDBServer(s grpc.ServiceRegistrar, srv RemoteDBServer) {
+	s.RegisterService(&RemoteDB_ServiceDesc, srv)
+}
+
+func _RemoteDB_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteDBServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: RemoteDB_Get_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteDBServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteDB_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteDBServer).Set(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: RemoteDB_Set_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteDBServer).Set(ctx, req.(*SetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteDB_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteDBServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: RemoteDB_Delete_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteDBServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteDB_Iterator_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(IteratorRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RemoteDBServer).Iterator(m, &remoteDBIteratorServer{stream})
+}
+
+func _RemoteDB_Batch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteDBServer).Batch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: RemoteDB_Batch_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteDBServer).Batch(ctx, req.(*BatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RemoteDB_ServiceDesc is the grpc.ServiceDesc for RemoteDB service.
+var RemoteDB_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "remotedb.RemoteDB",
+	HandlerType: (*RemoteDBServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Get", Handler: _RemoteDB_Get_Handler},
+		{MethodName: "Set", Handler: _RemoteDB_Set_Handler},
+		{MethodName: "Delete", Handler: _RemoteDB_Delete_Handler},
+		{MethodName: "Batch", Handler: _RemoteDB_Batch_Handler},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Iterator",
+			Handler:       _RemoteDB_Iterator_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "remotedb.proto",
+}